@@ -177,5 +177,117 @@ func testConda(t *testing.T, context spec.G, it spec.S) {
 				Expect(response.StatusCode).To(Equal(http.StatusOK))
 			})
 		})
+
+		context("when the environment.yml pins python and lists channels", func() {
+			it("resolves the pinned python from the given channels", func() {
+				var err error
+				source, err = occam.Source(filepath.Join("testdata", "conda"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(os.WriteFile(filepath.Join(source, "environment.yml"), []byte(`channels:
+- conda-forge
+dependencies:
+- python=3.9
+- flask
+`), os.ModePerm)).To(Succeed())
+
+				var logs fmt.Stringer
+				image, logs, err = pack.WithNoColor().Build.
+					WithBuildpacks(pythonBuildpack).
+					WithPullPolicy("never").
+					Execute(name, source)
+				Expect(err).NotTo(HaveOccurred(), logs.String())
+
+				Expect(logs).To(ContainLines(ContainSubstring("Conda Env Update Buildpack")))
+
+				container, err = docker.Container.Run.
+					WithEnv(map[string]string{"PORT": "8080"}).
+					WithPublish("8080").
+					WithPublishAll().
+					Execute(image.ID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(container).Should(BeAvailable())
+
+				response, err := http.Get(fmt.Sprintf("http://localhost:%s", container.HostPort("8080")))
+				Expect(err).NotTo(HaveOccurred())
+				defer response.Body.Close()
+
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				content, err := io.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(content)).To(ContainSubstring("Using python: 3.9."))
+			})
+		})
+
+		context("when the environment.yml contains a pip subsection", func() {
+			it("installs the pip-only packages into the conda environment", func() {
+				var err error
+				source, err = occam.Source(filepath.Join("testdata", "conda"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(os.WriteFile(filepath.Join(source, "environment.yml"), []byte(`dependencies:
+- python=3.9
+- flask
+- pip
+- pip:
+  - tabulate==0.8.9
+`), os.ModePerm)).To(Succeed())
+
+				build := pack.WithNoColor().Build.
+					WithBuildpacks(pythonBuildpack).
+					WithPullPolicy("never")
+
+				var logs fmt.Stringer
+				image, logs, container = buildAndLaunch(t, build, name, source,
+					`python -c "import tabulate; print('tabulate ' + tabulate.__version__)"`,
+					ContainSubstring("tabulate 0.8.9"))
+
+				Expect(logs).To(ContainLines(ContainSubstring("Successfully installed tabulate-0.8.9")))
+			})
+		})
+	})
+
+	context("failure cases", func() {
+		var (
+			name   string
+			source string
+		)
+
+		it.Before(func() {
+			var err error
+			name, err = occam.RandomName()
+			Expect(err).NotTo(HaveOccurred())
+
+			source, err = occam.Source(filepath.Join("testdata", "conda"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it.After(func() {
+			Expect(docker.Volume.Remove.Execute(occam.CacheVolumeNames(name))).To(Succeed())
+			Expect(os.RemoveAll(source)).To(Succeed())
+		})
+
+		context("when a package in the pip subsection cannot be installed", func() {
+			it.Before(func() {
+				Expect(os.WriteFile(filepath.Join(source, "environment.yml"), []byte(`dependencies:
+- python=3.9
+- pip
+- pip:
+  - paketo-package-that-does-not-exist==1.0.0
+`), os.ModePerm)).To(Succeed())
+			})
+
+			it("fails the build", func() {
+				_, logs, err := pack.WithNoColor().Build.
+					WithBuildpacks(pythonBuildpack).
+					WithPullPolicy("never").
+					Execute(name, source)
+				Expect(err).To(HaveOccurred(), logs.String())
+
+				Expect(logs).To(ContainLines(ContainSubstring("No matching distribution found for paketo-package-that-does-not-exist==1.0.0")))
+			})
+		})
 	})
 }
@@ -2,10 +2,17 @@ package integration_test
 
 import (
 	"bytes"
+	"fmt"
+	"net/http"
+	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/onsi/gomega/types"
+	"github.com/paketo-buildpacks/occam"
 	"github.com/paketo-buildpacks/packit/pexec"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
@@ -39,3 +46,89 @@ func TestIntegration(t *testing.T) {
 	suite("NoPackageManager", testNoPackageManager)
 	suite.Run(t)
 }
+
+// newPackageIndex returns a handler that serves the wheels in the given
+// directory as a PEP 503 simple repository. When a username is given, every
+// request must authenticate with the matching basic auth credentials.
+func newPackageIndex(dir, username, password string) http.Handler {
+	normalize := func(name string) string {
+		return strings.ToLower(regexp.MustCompile(`[-_.]+`).ReplaceAllString(name, "-"))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/simple/", func(w http.ResponseWriter, req *http.Request) {
+		project := normalize(strings.Trim(strings.TrimPrefix(req.URL.Path, "/simple/"), "/"))
+
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var links []string
+		for _, file := range files {
+			if normalize(strings.SplitN(file.Name(), "-", 2)[0]) == project {
+				links = append(links, fmt.Sprintf(`<a href="/packages/%[1]s">%[1]s</a>`, file.Name()))
+			}
+		}
+
+		if len(links) == 0 {
+			http.NotFound(w, req)
+			return
+		}
+
+		fmt.Fprintf(w, "<!DOCTYPE html>\n<html><body>\n%s\n</body></html>\n", strings.Join(links, "<br/>\n"))
+	})
+	mux.Handle("/packages/", http.StripPrefix("/packages/", http.FileServer(http.Dir(dir))))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if username != "" {
+			user, pass, ok := req.BasicAuth()
+			if !ok || user != username || pass != password {
+				w.Header().Set("WWW-Authenticate", `Basic realm="index"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		mux.ServeHTTP(w, req)
+	})
+}
+
+// launch runs command through the launcher in a container started from the
+// given image and waits for the container output to satisfy matcher. It
+// returns the container, which the caller is responsible for removing, along
+// with that output.
+func launch(t *testing.T, image occam.Image, command string, matcher types.GomegaMatcher) (occam.Container, string) {
+	docker := occam.NewDocker()
+
+	container, err := docker.Container.Run.
+		WithEntrypoint("launcher").
+		WithCommand(command).
+		Execute(image.ID)
+	NewWithT(t).Expect(err).NotTo(HaveOccurred())
+
+	var output string
+	NewWithT(t).Eventually(func() string {
+		logs, err := docker.Container.Logs.Execute(container.ID)
+		NewWithT(t).Expect(err).NotTo(HaveOccurred())
+
+		output = logs.String()
+		return output
+	}).Should(matcher)
+
+	return container, output
+}
+
+// buildAndLaunch builds source under name with the given pack build and then
+// launches command in the resulting image as launch does. It returns the
+// image and the container, which the caller is responsible for removing,
+// along with the build logs.
+func buildAndLaunch(t *testing.T, build occam.PackBuild, name, source, command string, matcher types.GomegaMatcher) (occam.Image, fmt.Stringer, occam.Container) {
+	image, logs, err := build.Execute(name, source)
+	NewWithT(t).Expect(err).NotTo(HaveOccurred(), logs.String())
+
+	container, _ := launch(t, image, command, matcher)
+
+	return image, logs, container
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -36,6 +37,7 @@ func testPip(t *testing.T, context spec.G, it spec.S) {
 			image     occam.Image
 			container occam.Container
 
+			build  occam.PackBuild
 			name   string
 			source string
 		)
@@ -44,6 +46,10 @@ func testPip(t *testing.T, context spec.G, it spec.S) {
 			var err error
 			name, err = occam.RandomName()
 			Expect(err).NotTo(HaveOccurred())
+
+			build = pack.WithNoColor().Build.
+				WithBuildpacks(pythonBuildpack).
+				WithPullPolicy("never")
 		})
 
 		it.After(func() {
@@ -181,5 +187,193 @@ func testPip(t *testing.T, context spec.G, it spec.S) {
 				Expect(response.StatusCode).To(Equal(http.StatusOK))
 			})
 		})
+
+		context("when the requirements.txt relies on pip's own syntax", func() {
+			context("when it pins hashes", func() {
+				it("installs the requirements with hash checking enabled", func() {
+					var err error
+					source, err = occam.Source(filepath.Join("testdata", "hashes"))
+					Expect(err).NotTo(HaveOccurred())
+
+					image, _, container = buildAndLaunch(t, build, name, source,
+						`python -c "import six; print('six version: ' + six.__version__)"`,
+						ContainSubstring("six version: 1.16.0"))
+				})
+			})
+
+			context("when it contains editable local paths", func() {
+				it("installs the local packages so they are importable at runtime", func() {
+					var err error
+					source, err = occam.Source(filepath.Join("testdata", "editable"))
+					Expect(err).NotTo(HaveOccurred())
+
+					var logs fmt.Stringer
+					image, logs, container = buildAndLaunch(t, build, name, source,
+						`cd / && python -c "import common; print(common.greeting())"`,
+						ContainSubstring("Hello from a local package!"))
+
+					Expect(logs).To(ContainLines(ContainSubstring("Pip Install Buildpack")))
+				})
+			})
+
+			context("when it contains an in-file --extra-index-url", func() {
+				var server *httptest.Server
+
+				it.Before(func() {
+					server = httptest.NewServer(newPackageIndex(filepath.Join("testdata", "private_index", "packages"), "some-user", "some-password"))
+				})
+
+				it.After(func() {
+					server.Close()
+				})
+
+				it("honors the directive and redacts its credentials from the logs", func() {
+					var err error
+					source, err = occam.Source(filepath.Join("testdata", "private_index", "app"))
+					Expect(err).NotTo(HaveOccurred())
+
+					requirements := fmt.Sprintf("--extra-index-url http://some-user:some-password@%s/simple\nsix==1.16.0\npaketo-private-package==1.0.0\n", server.Listener.Addr())
+					Expect(os.WriteFile(filepath.Join(source, "requirements.txt"), []byte(requirements), os.ModePerm)).To(Succeed())
+
+					var logs fmt.Stringer
+					image, logs, container = buildAndLaunch(t, build.WithNetwork("host"), name, source,
+						`python -c "import private_package, six; print(private_package.greeting()); print('six ' + six.__version__)"`,
+						And(
+							ContainSubstring("Hello from a private package!"),
+							ContainSubstring("six 1.16.0"),
+						))
+
+					Expect(logs).To(ContainLines(ContainSubstring(fmt.Sprintf("http://some-user:****@%s/simple", server.Listener.Addr()))))
+					Expect(logs.String()).NotTo(ContainSubstring("some-password"))
+				})
+			})
+
+			context("when it installs a package from vendor/", func() {
+				it("installs the vendored package so that it can be imported at runtime", func() {
+					var err error
+					source, err = occam.Source(filepath.Join("testdata", "vendored"))
+					Expect(err).NotTo(HaveOccurred())
+
+					image, _, container = buildAndLaunch(t, build, name, source,
+						`cd / && python -c "import greeter; print(greeter.greeting())"`,
+						ContainSubstring("Hello from a vendored package!"))
+				})
+			})
+
+			context("when it contains environment markers", func() {
+				it("installs only the applicable packages", func() {
+					var err error
+					source, err = occam.Source(filepath.Join("testdata", "pip"))
+					Expect(err).NotTo(HaveOccurred())
+
+					file, err := os.OpenFile(filepath.Join(source, "requirements.txt"), os.O_APPEND|os.O_WRONLY, 0600)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, err = file.WriteString(`six==1.16.0; python_version >= "3"
+pywin32==303; sys_platform == "win32"
+uvloop==0.16.0 ; sys_platform != 'win32' and platform_python_implementation == 'CPython'
+`)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(file.Close()).To(Succeed())
+
+					image, _, container = buildAndLaunch(t, build, name, source,
+						`python -c "import importlib.util, six, uvloop; print('six ' + six.__version__); print('uvloop ' + uvloop.__version__); print('pywin32 installed: %s' % (importlib.util.find_spec('win32api') is not None))"`,
+						And(
+							ContainSubstring("six 1.16.0"),
+							ContainSubstring("uvloop 0.16.0"),
+							ContainSubstring("pywin32 installed: False"),
+						))
+				})
+			})
+
+		})
+
+		context("when SOURCE_DATE_EPOCH is set", func() {
+			var (
+				firstImage     occam.Image
+				firstContainer occam.Container
+			)
+
+			it.After(func() {
+				Expect(docker.Container.Remove.Execute(firstContainer.ID)).To(Succeed())
+				Expect(docker.Image.Remove.Execute(firstImage.ID)).To(Succeed())
+			})
+
+			it("compiles the installed packages to identical hash-based bytecode on every build", func() {
+				var err error
+				source, err = occam.Source(filepath.Join("testdata", "pip"))
+				Expect(err).NotTo(HaveOccurred())
+
+				build = build.WithEnv(map[string]string{"SOURCE_DATE_EPOCH": "1700000000"})
+
+				// The second word of a .pyc header holds its flags: 0 for a
+				// timestamp-based file, 3 for a checked hash-based one.
+				command := `python -c "import flask, hashlib, importlib.util; data = open(importlib.util.cache_from_source(flask.__file__), 'rb').read(); print('flags=%d sha256=%s' % (int.from_bytes(data[4:8], 'little'), hashlib.sha256(data).hexdigest()))"`
+
+				var logs fmt.Stringer
+				firstImage, logs, err = build.Execute(name, source)
+				Expect(err).NotTo(HaveOccurred(), logs.String())
+
+				var firstBytecode string
+				firstContainer, firstBytecode = launch(t, firstImage, command, ContainSubstring("sha256="))
+				Expect(firstBytecode).To(HavePrefix("flags=3 "))
+
+				Expect(docker.Volume.Remove.Execute(occam.CacheVolumeNames(name))).To(Succeed())
+
+				image, logs, err = build.Execute(name, source)
+				Expect(err).NotTo(HaveOccurred(), logs.String())
+
+				var secondBytecode string
+				container, secondBytecode = launch(t, image, command, ContainSubstring("sha256="))
+				Expect(secondBytecode).To(Equal(firstBytecode))
+			})
+		})
+	})
+
+	context("failure cases", func() {
+		var (
+			build  occam.PackBuild
+			name   string
+			source string
+		)
+
+		it.Before(func() {
+			var err error
+			name, err = occam.RandomName()
+			Expect(err).NotTo(HaveOccurred())
+
+			build = pack.WithNoColor().Build.
+				WithBuildpacks(pythonBuildpack).
+				WithPullPolicy("never")
+
+			source, err = occam.Source(filepath.Join("testdata", "pip"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it.After(func() {
+			Expect(docker.Volume.Remove.Execute(occam.CacheVolumeNames(name))).To(Succeed())
+			Expect(os.RemoveAll(source)).To(Succeed())
+		})
+
+		context("when a pinned hash does not match the downloaded package", func() {
+			it.Before(func() {
+				Expect(os.RemoveAll(source)).To(Succeed())
+
+				var err error
+				source, err = occam.Source(filepath.Join("testdata", "hashes"))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(os.WriteFile(filepath.Join(source, "requirements.txt"), []byte(
+					"six==1.16.0 --hash=sha256:0000000000000000000000000000000000000000000000000000000000000000\n",
+				), os.ModePerm)).To(Succeed())
+			})
+
+			it("fails the build", func() {
+				_, logs, err := build.Execute(name, source)
+				Expect(err).To(HaveOccurred(), logs.String())
+
+				Expect(logs).To(ContainLines(ContainSubstring("THESE PACKAGES DO NOT MATCH THE HASHES FROM THE REQUIREMENTS FILE")))
+			})
+		})
 	})
 }